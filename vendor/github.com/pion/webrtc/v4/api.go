@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+// +build !js
+
+package webrtc
+
+import "github.com/pion/logging"
+
+// API bundles the global functions of the WebRTC and ORTC API. Some of these
+// functions are also exported globally using the default API. When the
+// default API is used, it is protected with a mutex, so it can be accessed
+// concurrently. This is not the case for the API type, so functions will
+// panic if you try to use them concurrently.
+type API struct {
+	settingEngine SettingEngine
+}
+
+// NewAPI allows the caller to pre-configure how ICE, DTLS and SRTP behave
+// before constructing any ICEGatherer/ICETransport on top of it.
+func NewAPI(options ...func(*API)) *API {
+	a := &API{}
+
+	for _, o := range options {
+		o(a)
+	}
+
+	if a.settingEngine.LoggerFactory == nil {
+		a.settingEngine.LoggerFactory = logging.NewDefaultLoggerFactory()
+	}
+
+	return a
+}
+
+// WithSettingEngine allows providing a SettingEngine to the API.
+// Settings should not be changed after passing the engine to an API.
+func WithSettingEngine(s SettingEngine) func(*API) {
+	return func(a *API) {
+		a.settingEngine = s
+	}
+}
+
+// NewICEGatherer creates a new NewICEGatherer, which is used to gather local
+// ICE candidates and expose the local ICE parameters of this API's
+// SettingEngine.
+func (api *API) NewICEGatherer(ICEGatherOptions) (*ICEGatherer, error) {
+	return &ICEGatherer{api: api}, nil
+}
@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+// +build !js
+
+package webrtc
+
+import "errors"
+
+// ErrUnknownType indicates an error with Unknown info.
+var ErrUnknownType = errors.New("unknown")
+
+var (
+	// errICEMDNSResolutionFailed indicates that a ".local" remote candidate
+	// could not be resolved to a routable address before
+	// iceMDNSResolutionTimeout elapsed.
+	errICEMDNSResolutionFailed = errors.New("webrtc: mDNS resolution of remote candidate failed")
+
+	// errICETCPFrameTooLarge indicates a packet handed to rfc4571FramedConn
+	// is too large to be represented by the 2-byte RFC 4571 length prefix.
+	errICETCPFrameTooLarge = errors.New("webrtc: packet too large for RFC 4571 framing")
+
+	// errICEProtocolUnknown indicates NewICEProtocol was called with an
+	// unsupported transport protocol string.
+	errICEProtocolUnknown = errors.New("webrtc: unknown ICE protocol")
+
+	// errICEInvalidConvertCandidateType indicates ICECandidate.toICE was
+	// called on a candidate whose Typ has no ice.Candidate equivalent.
+	errICEInvalidConvertCandidateType = errors.New("webrtc: cannot convert ICE candidate type")
+
+	// errICETransportNotStarted indicates WriteSTUNBinding was called before
+	// the ICETransport has an active connection to write to.
+	errICETransportNotStarted = errors.New("webrtc: ICETransport has not been started")
+
+	// errICECandidatePairSelectorFailed indicates the ICE agent rejected the
+	// candidate pair returned by a SettingEngine candidate pair selector,
+	// e.g. because the pair no longer exists.
+	errICECandidatePairSelectorFailed = errors.New("webrtc: failed to nominate candidate pair returned by selector")
+)
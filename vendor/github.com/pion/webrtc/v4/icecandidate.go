@@ -0,0 +1,164 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+// +build !js
+
+package webrtc
+
+import (
+	"fmt"
+
+	"github.com/pion/ice/v4"
+)
+
+// ICECandidate represents a ice candidate.
+type ICECandidate struct {
+	statsID        string
+	Foundation     string
+	Priority       uint32
+	Address        string
+	Protocol       ICEProtocol
+	Port           uint16
+	Typ            ICECandidateType
+	Component      uint16
+	RelatedAddress string
+	RelatedPort    uint16
+	TCPType        ICECandidateTCPType
+}
+
+// newICECandidateFromICE converts an ice.Candidate into its webrtc
+// representation.
+func newICECandidateFromICE(i ice.Candidate, sdpMid string, sdpMLineIndex int) (ICECandidate, error) { //nolint:unparam
+	typ, err := newICECandidateTypeFromICE(i.Type())
+	if err != nil {
+		return ICECandidate{}, err
+	}
+
+	protocol, err := NewICEProtocol(i.NetworkType().NetworkShort())
+	if err != nil {
+		return ICECandidate{}, err
+	}
+
+	c := ICECandidate{
+		statsID:    i.ID(),
+		Foundation: i.Foundation(),
+		Priority:   i.Priority(),
+		Address:    i.Address(),
+		Protocol:   protocol,
+		Port:       uint16(i.Port()), //nolint:gosec
+		Component:  i.Component(),
+		Typ:        typ,
+		TCPType:    NewICECandidateTCPType(i.TCPType().String()),
+	}
+
+	if relatedAddr := i.RelatedAddress(); relatedAddr != nil {
+		c.RelatedAddress = relatedAddr.Address
+		c.RelatedPort = uint16(relatedAddr.Port) //nolint:gosec
+	}
+
+	return c, nil
+}
+
+// newICECandidatesFromICE converts a slice of ice.Candidate into their
+// webrtc representation.
+func newICECandidatesFromICE(iceCandidates []ice.Candidate, sdpMid string, sdpMLineIndex int) ([]ICECandidate, error) {
+	candidates := make([]ICECandidate, len(iceCandidates))
+
+	for i, iceCandidate := range iceCandidates {
+		c, err := newICECandidateFromICE(iceCandidate, sdpMid, sdpMLineIndex)
+		if err != nil {
+			return nil, err
+		}
+
+		candidates[i] = c
+	}
+
+	return candidates, nil
+}
+
+// toICE converts a webrtc ICECandidate back into an ice.Candidate, the
+// inverse of newICECandidateFromICE.
+func (c ICECandidate) toICE() (ice.Candidate, error) { //nolint:cyclop
+	candidateID := c.statsID
+
+	switch c.Typ {
+	case ICECandidateTypeHost:
+		config := ice.CandidateHostConfig{
+			Network:     c.Protocol.String(),
+			Address:     c.Address,
+			Port:        int(c.Port),
+			Component:   c.Component,
+			Priority:    c.Priority,
+			Foundation:  c.Foundation,
+			CandidateID: candidateID,
+			TCPType:     ice.NewTCPType(c.TCPType.String()),
+		}
+		candidate, err := ice.NewCandidateHost(&config)
+		if err != nil {
+			return nil, err
+		}
+
+		return candidate, nil
+	case ICECandidateTypeSrflx:
+		config := ice.CandidateServerReflexiveConfig{
+			Network:     c.Protocol.String(),
+			Address:     c.Address,
+			Port:        int(c.Port),
+			Component:   c.Component,
+			Priority:    c.Priority,
+			Foundation:  c.Foundation,
+			CandidateID: candidateID,
+			RelAddr:     c.RelatedAddress,
+			RelPort:     int(c.RelatedPort),
+		}
+
+		return ice.NewCandidateServerReflexive(&config)
+	case ICECandidateTypePrflx:
+		config := ice.CandidatePeerReflexiveConfig{
+			Network:     c.Protocol.String(),
+			Address:     c.Address,
+			Port:        int(c.Port),
+			Component:   c.Component,
+			Priority:    c.Priority,
+			Foundation:  c.Foundation,
+			CandidateID: candidateID,
+			RelAddr:     c.RelatedAddress,
+			RelPort:     int(c.RelatedPort),
+		}
+
+		return ice.NewCandidatePeerReflexive(&config)
+	case ICECandidateTypeRelay:
+		config := ice.CandidateRelayConfig{
+			Network:     c.Protocol.String(),
+			Address:     c.Address,
+			Port:        int(c.Port),
+			Component:   c.Component,
+			Priority:    c.Priority,
+			Foundation:  c.Foundation,
+			CandidateID: candidateID,
+			RelAddr:     c.RelatedAddress,
+			RelPort:     int(c.RelatedPort),
+		}
+
+		return ice.NewCandidateRelay(&config)
+	default:
+		return nil, fmt.Errorf("%w: %s", errICEInvalidConvertCandidateType, c.Typ)
+	}
+}
+
+// ICECandidatePair represents an ICE candidate pair used during connectivity
+// checks.
+type ICECandidatePair struct {
+	Local  *ICECandidate
+	Remote *ICECandidate
+}
+
+// NewICECandidatePair returns an initialized ICECandidatePair for the given
+// local and remote candidates.
+func NewICECandidatePair(local, remote *ICECandidate) *ICECandidatePair {
+	return &ICECandidatePair{
+		Local:  local,
+		Remote: remote,
+	}
+}
@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+// +build !js
+
+package webrtc
+
+// ICECandidateTCPType represents the type of TCP candidate, as described in
+// https://tools.ietf.org/html/rfc6544#section-4.5.
+type ICECandidateTCPType int
+
+const (
+	// ICECandidateTCPTypeUnspecified is provided for UDP candidates, which
+	// have no TCP type.
+	ICECandidateTCPTypeUnspecified ICECandidateTCPType = iota
+
+	// ICECandidateTCPTypeActive is as described in
+	// https://tools.ietf.org/html/rfc6544#section-4.5.
+	ICECandidateTCPTypeActive
+
+	// ICECandidateTCPTypePassive is as described in
+	// https://tools.ietf.org/html/rfc6544#section-4.5.
+	ICECandidateTCPTypePassive
+
+	// ICECandidateTCPTypeSoActive is as described in
+	// https://tools.ietf.org/html/rfc6544#section-4.5.
+	ICECandidateTCPTypeSoActive
+)
+
+func (t ICECandidateTCPType) String() string {
+	switch t {
+	case ICECandidateTCPTypeActive:
+		return "active"
+	case ICECandidateTCPTypePassive:
+		return "passive"
+	case ICECandidateTCPTypeSoActive:
+		return "so"
+	default:
+		return ""
+	}
+}
+
+// NewICECandidateTCPType creates a new ICECandidateTCPType from a string,
+// defaulting to ICECandidateTCPTypeUnspecified for unrecognized values (e.g.
+// UDP candidates, which carry no "tcptype" SDP attribute).
+func NewICECandidateTCPType(raw string) ICECandidateTCPType {
+	switch raw {
+	case "active":
+		return ICECandidateTCPTypeActive
+	case "passive":
+		return ICECandidateTCPTypePassive
+	case "so":
+		return ICECandidateTCPTypeSoActive
+	default:
+		return ICECandidateTCPTypeUnspecified
+	}
+}
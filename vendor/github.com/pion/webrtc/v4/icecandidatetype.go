@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+// +build !js
+
+package webrtc
+
+import (
+	"fmt"
+
+	"github.com/pion/ice/v4"
+)
+
+// ICECandidateType represents the type of the ICE candidate used.
+type ICECandidateType int
+
+const (
+	// ICECandidateTypeHost indicates that the candidate is of Host type as
+	// described in https://tools.ietf.org/html/rfc8445#section-5.1.1.1. A
+	// candidate obtained by binding to a specific port from an IP address on
+	// the host. This includes IP addresses on physical interfaces and
+	// logical ones, such as ones obtained through VPNs.
+	ICECandidateTypeHost ICECandidateType = iota + 1
+
+	// ICECandidateTypeSrflx indicates the candidate is of Server
+	// Reflexive type as described
+	// https://tools.ietf.org/html/rfc8445#section-5.1.1.2. A candidate type
+	// whose IP address and port are a binding allocated by a NAT for an ICE
+	// agent after it sends a packet through the NAT to a server, such as a
+	// STUN server.
+	ICECandidateTypeSrflx
+
+	// ICECandidateTypePrflx indicates that the candidate is of Peer
+	// Reflexive type. A candidate type whose IP address and port are a
+	// binding allocated by a NAT for an ICE agent after it sends a packet
+	// through the NAT to its peer.
+	ICECandidateTypePrflx
+
+	// ICECandidateTypeRelay indicates the candidate is of Relay type,
+	// obtained from a TURN server. The candidate is resolved by sending a
+	// TURN Allocate request from a host candidate to a TURN server.
+	ICECandidateTypeRelay
+)
+
+func (t ICECandidateType) String() string {
+	switch t {
+	case ICECandidateTypeHost:
+		return "host"
+	case ICECandidateTypeSrflx:
+		return "srflx"
+	case ICECandidateTypePrflx:
+		return "prflx"
+	case ICECandidateTypeRelay:
+		return "relay"
+	default:
+		return ErrUnknownType.Error()
+	}
+}
+
+// newICECandidateTypeFromICE converts between ice.CandidateType and
+// ICECandidateType.
+func newICECandidateTypeFromICE(t ice.CandidateType) (ICECandidateType, error) {
+	switch t {
+	case ice.CandidateTypeHost:
+		return ICECandidateTypeHost, nil
+	case ice.CandidateTypeServerReflexive:
+		return ICECandidateTypeSrflx, nil
+	case ice.CandidateTypePeerReflexive:
+		return ICECandidateTypePrflx, nil
+	case ice.CandidateTypeRelay:
+		return ICECandidateTypeRelay, nil
+	default:
+		return ICECandidateType(t), fmt.Errorf("%w: %s", errICEInvalidConvertCandidateType, t)
+	}
+}
+
+func (t ICECandidateType) toICE() (ice.CandidateType, error) {
+	switch t {
+	case ICECandidateTypeHost:
+		return ice.CandidateTypeHost, nil
+	case ICECandidateTypeSrflx:
+		return ice.CandidateTypeServerReflexive, nil
+	case ICECandidateTypePrflx:
+		return ice.CandidateTypePeerReflexive, nil
+	case ICECandidateTypeRelay:
+		return ice.CandidateTypeRelay, nil
+	default:
+		return ice.CandidateType(t), fmt.Errorf("%w: %s", errICEInvalidConvertCandidateType, t)
+	}
+}
@@ -0,0 +1,271 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+// +build !js
+
+package webrtc
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+	"github.com/pion/ice/v4"
+)
+
+// mdnsCandidateName generates the random "<uuid>.local" name used to mask a
+// gathered host candidate's address when SettingEngine's MulticastDNSMode is
+// ICEMulticastDNSModeQueryAndGather.
+func mdnsCandidateName() string {
+	return fmt.Sprintf("%s.local", uuid.New().String())
+}
+
+// ICEGatherOptions provides options relating to the gathering of ICE
+// candidates.
+type ICEGatherOptions struct{}
+
+// ICEGatherer gathers local host, server reflexive and relay candidates, as
+// well as enabling the retrieval of local Interactive Connectivity
+// Establishment (ICE) parameters which can be exchanged in signaling.
+type ICEGatherer struct {
+	lock sync.RWMutex
+
+	api *API
+
+	agent *ice.Agent
+
+	onLocalCandidateHandler atomic.Value // func(*ICECandidate)
+}
+
+func (g *ICEGatherer) getAgent() *ice.Agent {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	return g.agent
+}
+
+// createAgent lazily creates the ice.Agent backing this gatherer, applying
+// every ICE-related option configured on the SettingEngine.
+func (g *ICEGatherer) createAgent() error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if g.agent != nil {
+		return nil
+	}
+
+	agent, err := ice.NewAgent(g.api.settingEngine.toICEAgentConfig())
+	if err != nil {
+		return err
+	}
+
+	if err := agent.OnCandidate(func(candidate ice.Candidate) {
+		g.onLocalCandidate(candidate)
+	}); err != nil {
+		_ = agent.Close() //nolint:errcheck
+		return err
+	}
+
+	g.agent = agent
+
+	return nil
+}
+
+// Gather starts ICE candidate gathering, lazily creating the agent if it
+// doesn't already exist. Safe to call again after a Restart.
+func (g *ICEGatherer) Gather() error {
+	if err := g.createAgent(); err != nil {
+		return err
+	}
+
+	return g.getAgent().GatherCandidates()
+}
+
+// Close prunes all local candidates, and closes the ports.
+func (g *ICEGatherer) Close() error {
+	agent := g.getAgent()
+	if agent == nil {
+		return nil
+	}
+
+	return agent.Close()
+}
+
+// GracefulClose closes the gatherer, but waits for any gathering that is
+// still in flight to complete first.
+func (g *ICEGatherer) GracefulClose() error {
+	agent := g.getAgent()
+	if agent == nil {
+		return nil
+	}
+
+	return agent.GracefulClose()
+}
+
+// GetLocalParameters returns the ICE parameters of the ice.Agent backing
+// this gatherer, creating it first if necessary.
+func (g *ICEGatherer) GetLocalParameters() (ICEParameters, error) {
+	if err := g.createAgent(); err != nil {
+		return ICEParameters{}, err
+	}
+
+	ufrag, pwd, err := g.getAgent().GetLocalUserCredentials()
+	if err != nil {
+		return ICEParameters{}, err
+	}
+
+	return ICEParameters{UsernameFragment: ufrag, Password: pwd}, nil
+}
+
+// OnLocalCandidate sets a handler that is invoked whenever a new local ICE
+// candidate is gathered. The handler is called with nil once gathering has
+// completed.
+func (g *ICEGatherer) OnLocalCandidate(f func(*ICECandidate)) {
+	g.onLocalCandidateHandler.Store(f)
+}
+
+func (g *ICEGatherer) onLocalCandidate(candidate ice.Candidate) {
+	handler, ok := g.onLocalCandidateHandler.Load().(func(*ICECandidate))
+	if !ok {
+		return
+	}
+
+	if candidate == nil {
+		handler(nil)
+		return
+	}
+
+	c, err := newICECandidateFromICE(candidate, "", 0)
+	if err != nil {
+		g.api.settingEngine.LoggerFactory.NewLogger("ice").Warnf("Failed to convert ice.Candidate: %s", err)
+		return
+	}
+
+	// When mDNS gathering is enabled, host candidates are advertised under a
+	// generated "<uuid>.local" name instead of their raw IP, so callers never
+	// need to munge the SDP to hide the local address.
+	if g.api.settingEngine.candidates.MulticastDNSMode == ICEMulticastDNSModeQueryAndGather &&
+		candidate.Type() == ice.CandidateTypeHost {
+		c.Address = mdnsCandidateName()
+	}
+
+	handler(&c)
+}
+
+// getSelectedCandidatePairStats returns the stats for the currently selected
+// candidate pair, if one has been nominated.
+func (g *ICEGatherer) getSelectedCandidatePairStats() (ICECandidatePairStats, bool) {
+	agent := g.getAgent()
+	if agent == nil {
+		return ICECandidatePairStats{}, false
+	}
+
+	icePair, err := agent.GetSelectedCandidatePair()
+	if icePair == nil || err != nil {
+		return ICECandidatePairStats{}, false
+	}
+
+	for _, pairStats := range g.getCandidatePairsStats() {
+		if pairStats.LocalID == icePair.Local.ID() && pairStats.RemoteID == icePair.Remote.ID() {
+			return pairStats, true
+		}
+	}
+
+	return ICECandidatePairStats{}, false
+}
+
+// getCandidatePairsStats returns stats for every candidate pair the ICE
+// agent has ever checked, not just the one currently selected. Local/Remote
+// on each entry are populated whenever the agent still knows about both
+// candidates the pair refers to.
+func (g *ICEGatherer) getCandidatePairsStats() []ICECandidatePairStats {
+	agent := g.getAgent()
+	if agent == nil {
+		return nil
+	}
+
+	localByID := candidatesByID(agent.GetLocalCandidates())
+	remoteByID := candidatesByID(agent.GetRemoteCandidates())
+
+	icePairStats := agent.GetCandidatePairsStats()
+	pairStats := make([]ICECandidatePairStats, 0, len(icePairStats))
+	for _, s := range icePairStats {
+		stats := newICECandidatePairStatsFromICE(s)
+
+		if local, ok := localByID[s.LocalCandidateID]; ok {
+			if c, err := newICECandidateFromICE(local, "", 0); err == nil {
+				stats.Local = &c
+			}
+		}
+		if remote, ok := remoteByID[s.RemoteCandidateID]; ok {
+			if c, err := newICECandidateFromICE(remote, "", 0); err == nil {
+				stats.Remote = &c
+			}
+		}
+
+		pairStats = append(pairStats, stats)
+	}
+
+	return pairStats
+}
+
+// candidatesByID indexes candidates, as returned by ice.Agent's
+// GetLocalCandidates/GetRemoteCandidates, by their opaque candidate ID.
+// Errors from the agent are treated as "no candidates known" rather than
+// propagated, since losing the Local/Remote stats fields is harmless.
+func candidatesByID(candidates []ice.Candidate, err error) map[string]ice.Candidate {
+	byID := make(map[string]ice.Candidate, len(candidates))
+	if err != nil {
+		return byID
+	}
+
+	for _, c := range candidates {
+		byID[c.ID()] = c
+	}
+
+	return byID
+}
+
+// newICECandidatePairStatsFromICE converts an ice.CandidatePairStats,
+// deriving a stable report ID from the pair's local/remote candidate IDs so
+// the same pair keeps the same ID across successive stats collections.
+func newICECandidatePairStatsFromICE(s ice.CandidatePairStats) ICECandidatePairStats {
+	return ICECandidatePairStats{
+		Timestamp:                   statsTimestampFrom(s.Timestamp),
+		Type:                        StatsTypeCandidatePair,
+		ID:                          fmt.Sprintf("%s-%s", s.LocalCandidateID, s.RemoteCandidateID),
+		LocalID:                     s.LocalCandidateID,
+		RemoteID:                    s.RemoteCandidateID,
+		State:                       newStatsICECandidatePairStateFromICE(s.State),
+		Nominated:                   s.Nominated,
+		BytesSent:                   s.BytesSent,
+		BytesReceived:               s.BytesReceived,
+		TotalRoundTripTime:          s.TotalRoundTripTime,
+		CurrentRoundTripTime:        s.CurrentRoundTripTime,
+		RequestsSent:                s.RequestsSent,
+		RequestsReceived:            s.RequestsReceived,
+		ResponsesSent:               s.ResponsesSent,
+		ResponsesReceived:           s.ResponsesReceived,
+		LastPacketSentTimestamp:     statsTimestampFrom(s.LastPacketSentTimestamp),
+		LastPacketReceivedTimestamp: statsTimestampFrom(s.LastPacketReceivedTimestamp),
+	}
+}
+
+// newStatsICECandidatePairStateFromICE converts an ice.CandidatePairState to
+// its StatsICECandidatePairState equivalent.
+func newStatsICECandidatePairStateFromICE(state ice.CandidatePairState) StatsICECandidatePairState {
+	switch state {
+	case ice.CandidatePairStateWaiting:
+		return StatsICECandidatePairStateWaiting
+	case ice.CandidatePairStateInProgress:
+		return StatsICECandidatePairStateInProgress
+	case ice.CandidatePairStateFailed:
+		return StatsICECandidatePairStateFailed
+	case ice.CandidatePairStateSucceeded:
+		return StatsICECandidatePairStateSucceeded
+	default:
+		return StatsICECandidatePairStateFailed
+	}
+}
@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+// +build !js
+
+package webrtc
+
+// ICEMulticastDNSMode controls whether the ICE agent queries and/or gathers
+// mDNS ("*.local") candidates, mirroring ice.MulticastDNSMode.
+type ICEMulticastDNSMode int
+
+const (
+	// ICEMulticastDNSModeDisabled means remote mDNS candidates are discarded
+	// and no local mDNS candidates are gathered. This is the default.
+	ICEMulticastDNSModeDisabled ICEMulticastDNSMode = iota + 1
+
+	// ICEMulticastDNSModeQueryOnly means remote mDNS candidates are resolved,
+	// but local candidates are still advertised with their real addresses.
+	ICEMulticastDNSModeQueryOnly
+
+	// ICEMulticastDNSModeQueryAndGather means remote mDNS candidates are
+	// resolved, and local host candidates are advertised under a generated
+	// "<uuid>.local" name instead of their real address.
+	ICEMulticastDNSModeQueryAndGather
+)
+
+// String implements fmt.Stringer.
+func (m ICEMulticastDNSMode) String() string {
+	switch m {
+	case ICEMulticastDNSModeQueryOnly:
+		return "query-only"
+	case ICEMulticastDNSModeQueryAndGather:
+		return "query-and-gather"
+	case ICEMulticastDNSModeDisabled:
+		return "disabled"
+	default:
+		return "disabled"
+	}
+}
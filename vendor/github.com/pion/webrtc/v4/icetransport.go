@@ -8,17 +8,28 @@ package webrtc
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
+	"io"
+	"net"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/pion/ice/v4"
 	"github.com/pion/logging"
+	"github.com/pion/mdns/v2"
+	"github.com/pion/stun/v3"
 	"github.com/pion/webrtc/v4/internal/mux"
 	"github.com/pion/webrtc/v4/internal/util"
+	"golang.org/x/net/ipv4"
 )
 
+// iceMDNSResolutionTimeout bounds how long AddRemoteCandidate will wait for a
+// ".local" remote candidate to resolve before giving up.
+const iceMDNSResolutionTimeout = 5 * time.Second
+
 // ICETransport allows an application access to information about the ICE
 // transport over which packets are sent and received.
 type ICETransport struct {
@@ -29,6 +40,7 @@ type ICETransport struct {
 	onConnectionStateChangeHandler         atomic.Value // func(ICETransportState)
 	internalOnConnectionStateChangeHandler atomic.Value // func(ICETransportState)
 	onSelectedCandidatePairChangeHandler   atomic.Value // func(*ICECandidatePair)
+	onRestartHandler                       atomic.Value // func()
 
 	state atomic.Value // ICETransportState
 
@@ -76,6 +88,40 @@ func (t *ICETransport) GetSelectedCandidatePairStats() (ICECandidatePairStats, b
 	return t.gatherer.getSelectedCandidatePairStats()
 }
 
+// GetCandidatePairsStats returns stats for every candidate pair known to the
+// ICE agent, not just the one currently selected.
+func (t *ICETransport) GetCandidatePairsStats() []ICECandidatePairStats {
+	return t.gatherer.getCandidatePairsStats()
+}
+
+// maybeSelectCandidatePair invokes the SettingEngine's candidate pair
+// selector, if any, once every known pair has reached the Succeeded state,
+// and asks the agent to nominate whichever pair it returns. It is only ever
+// called from the agent's own task loop (via agent.AfterRun), so it never
+// races with the agent's own connectivity checks.
+func (t *ICETransport) maybeSelectCandidatePair(selector func([]ICECandidatePairStats) *ICECandidatePair) {
+	pairs := t.GetCandidatePairsStats()
+	for _, p := range pairs {
+		if p.State != StatsICECandidatePairStateSucceeded {
+			return
+		}
+	}
+
+	pair := selector(pairs)
+	if pair == nil {
+		return
+	}
+
+	agent := t.gatherer.getAgent()
+	if agent == nil {
+		return
+	}
+
+	if err := agent.SetSelectedCandidatePair(pair.Local.Foundation, pair.Remote.Foundation); err != nil {
+		t.log.Warnf("%s: %s", errICECandidatePairSelectorFailed, err)
+	}
+}
+
 // NewICETransport creates a new NewICETransport.
 func NewICETransport(gatherer *ICEGatherer, loggerFactory logging.LoggerFactory) *ICETransport {
 	iceTransport := &ICETransport{
@@ -128,6 +174,14 @@ func (t *ICETransport) Start(gatherer *ICEGatherer, params ICEParameters, role *
 		return err
 	}
 
+	if selector := t.gatherer.api.settingEngine.candidatePairSelector; selector != nil {
+		if err := agent.AfterRun(func(context.Context) {
+			t.maybeSelectCandidatePair(selector)
+		}); err != nil {
+			return err
+		}
+	}
+
 	if role == nil {
 		controlled := ICERoleControlled
 		role = &controlled
@@ -169,8 +223,13 @@ func (t *ICETransport) Start(gatherer *ICEGatherer, params ICEParameters, role *
 
 	t.conn = iceConn
 
+	var muxConn net.Conn = iceConn
+	if pair, pairErr := agent.GetSelectedCandidatePair(); pairErr == nil && pair != nil && isTCPCandidatePair(pair) {
+		muxConn = newRFC4571FramedConn(iceConn)
+	}
+
 	config := mux.Config{
-		Conn:          t.conn,
+		Conn:          muxConn,
 		BufferSize:    int(t.gatherer.api.settingEngine.getReceiveMTU()),
 		LoggerFactory: t.loggerFactory,
 	}
@@ -179,9 +238,66 @@ func (t *ICETransport) Start(gatherer *ICEGatherer, params ICEParameters, role *
 	return nil
 }
 
-// restart is not exposed currently because ORTC has users create a whole new ICETransport
-// so for now lets keep it private so we don't cause ORTC users to depend on non-standard APIs
-func (t *ICETransport) restart() error {
+// isTCPCandidatePair reports whether either side of the negotiated candidate
+// pair is an ICE-TCP candidate.
+func isTCPCandidatePair(pair *ice.CandidatePair) bool {
+	return pair.Local.NetworkType().IsTCP() || pair.Remote.NetworkType().IsTCP()
+}
+
+// rfc4571FramedConn wraps an ice.Conn carrying traffic over a TCP candidate
+// pair with the RFC 4571 2-byte length-prefix framing, so that DTLS/SRTP
+// endpoints built on top of mux still see a datagram-shaped stream.
+type rfc4571FramedConn struct {
+	*ice.Conn
+}
+
+func newRFC4571FramedConn(conn *ice.Conn) *rfc4571FramedConn {
+	return &rfc4571FramedConn{Conn: conn}
+}
+
+func (c *rfc4571FramedConn) Read(b []byte) (int, error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(c.Conn, header); err != nil {
+		return 0, err
+	}
+
+	length := int(binary.BigEndian.Uint16(header))
+	if length > len(b) {
+		// Drain the oversized frame's payload from the underlying stream
+		// before reporting the error, otherwise the next Read would
+		// reinterpret its tail bytes as a new frame header and permanently
+		// desync the connection.
+		if _, err := io.CopyN(io.Discard, c.Conn, int64(length)); err != nil {
+			return 0, err
+		}
+		return 0, io.ErrShortBuffer
+	}
+
+	return io.ReadFull(c.Conn, b[:length])
+}
+
+func (c *rfc4571FramedConn) Write(b []byte) (int, error) {
+	if len(b) > 0xFFFF {
+		return 0, errICETCPFrameTooLarge
+	}
+
+	header := make([]byte, 2)
+	binary.BigEndian.PutUint16(header, uint16(len(b)))
+
+	if _, err := c.Conn.Write(append(header, b...)); err != nil {
+		return 0, err
+	}
+
+	return len(b), nil
+}
+
+// Restart rotates the local ICE username fragment and password and restarts
+// the underlying ICE agent in place. Unlike creating a new ICETransport, the
+// existing mux and conn are left untouched, so DTLS/SRTP endpoints obtained
+// via newEndpoint stay valid across the restart. The agent takes care of
+// rejecting any remote candidates that still reference the credentials from
+// before the restart.
+func (t *ICETransport) Restart(params ICEParameters) error {
 	t.lock.Lock()
 	defer t.lock.Unlock()
 
@@ -190,10 +306,22 @@ func (t *ICETransport) restart() error {
 		return fmt.Errorf("%w: unable to restart ICETransport", errICEAgentNotExist)
 	}
 
-	if err := agent.Restart(t.gatherer.api.settingEngine.candidates.UsernameFragment, t.gatherer.api.settingEngine.candidates.Password); err != nil {
+	ufrag, pwd := params.UsernameFragment, params.Password
+	if ufrag == "" || pwd == "" {
+		ufrag, pwd = t.gatherer.api.settingEngine.candidates.UsernameFragment, t.gatherer.api.settingEngine.candidates.Password
+	}
+
+	if err := agent.Restart(ufrag, pwd); err != nil {
 		return err
 	}
-	return t.gatherer.Gather()
+
+	if err := t.gatherer.Gather(); err != nil {
+		return err
+	}
+
+	t.onRestart()
+
+	return nil
 }
 
 // Stop irreversibly stops the ICETransport.
@@ -252,7 +380,12 @@ func (t *ICETransport) onSelectedCandidatePairChange(pair *ICECandidatePair) {
 }
 
 // OnConnectionStateChange sets a handler that is fired when the ICE
-// connection state changes.
+// connection state changes. The underlying ice.Agent always reports
+// ICETransportStateDisconnected before ICETransportStateFailed, giving the
+// caller a chance to react to transient connectivity loss (e.g. a mobile
+// link flap) before the agent gives up entirely; how long the agent waits in
+// each state is controlled by the disconnect/failed timeouts configured on
+// the SettingEngine.
 func (t *ICETransport) OnConnectionStateChange(f func(ICETransportState)) {
 	t.onConnectionStateChangeHandler.Store(f)
 }
@@ -266,6 +399,19 @@ func (t *ICETransport) onConnectionStateChange(state ICETransportState) {
 	}
 }
 
+// OnRestart sets a handler that is invoked after a successful ICE restart,
+// once the agent has been restarted under the rotated credentials and
+// gathering has been re-triggered.
+func (t *ICETransport) OnRestart(f func()) {
+	t.onRestartHandler.Store(f)
+}
+
+func (t *ICETransport) onRestart() {
+	if handler, ok := t.onRestartHandler.Load().(func()); ok {
+		handler()
+	}
+}
+
 // Role indicates the current role of the ICE transport.
 func (t *ICETransport) Role() ICERole {
 	t.lock.RLock()
@@ -317,6 +463,18 @@ func (t *ICETransport) AddRemoteCandidate(remoteCandidate *ICECandidate) error {
 	}
 
 	if remoteCandidate != nil {
+		if strings.HasSuffix(remoteCandidate.Address, ".local") {
+			mode := t.gatherer.api.settingEngine.candidates.MulticastDNSMode
+			if mode != ICEMulticastDNSModeQueryOnly && mode != ICEMulticastDNSModeQueryAndGather {
+				t.log.Warnf("Remote mDNS candidate %s discarded, mDNS is disabled", remoteCandidate.Address)
+				return nil
+			}
+
+			if err = t.resolveMDNSCandidate(remoteCandidate); err != nil {
+				return err
+			}
+		}
+
 		if c, err = remoteCandidate.toICE(); err != nil {
 			return err
 		}
@@ -330,6 +488,40 @@ func (t *ICETransport) AddRemoteCandidate(remoteCandidate *ICECandidate) error {
 	return agent.AddRemoteCandidate(c)
 }
 
+// resolveMDNSCandidate resolves a remote candidate's ".local" address to the
+// IP it actually advertises on the multicast DNS network, replacing
+// remoteCandidate.Address in place so the ICE agent only ever sees resolved
+// addresses.
+func (t *ICETransport) resolveMDNSCandidate(remoteCandidate *ICECandidate) error {
+	ctx, cancel := context.WithTimeout(context.Background(), iceMDNSResolutionTimeout)
+	defer cancel()
+
+	addr, err := net.ResolveUDPAddr("udp4", mdns.DefaultAddress)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errICEMDNSResolutionFailed, err)
+	}
+
+	l, err := net.ListenUDP("udp4", addr)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errICEMDNSResolutionFailed, err)
+	}
+
+	conn, err := mdns.Server(ipv4.NewPacketConn(l), &mdns.Config{})
+	if err != nil {
+		return fmt.Errorf("%w: %s", errICEMDNSResolutionFailed, err)
+	}
+	defer conn.Close() //nolint:errcheck
+
+	_, resolved, err := conn.Query(ctx, remoteCandidate.Address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errICEMDNSResolutionFailed, err)
+	}
+
+	remoteCandidate.Address = resolved.String()
+
+	return nil
+}
+
 // State returns the current ice transport state.
 func (t *ICETransport) State() ICETransportState {
 	if v, ok := t.state.Load().(ICETransportState); ok {
@@ -348,6 +540,53 @@ func (t *ICETransport) GetLocalParameters() (ICEParameters, error) {
 	return t.gatherer.GetLocalParameters()
 }
 
+// WriteSTUNBinding sends an explicit STUN binding request over the current
+// ICE connection, outside of the agent's own consent-freshness/keepalive
+// schedule. This is useful for applications that need to keep a symmetric
+// NAT binding alive during stretches where the transport is otherwise idle.
+func (t *ICETransport) WriteSTUNBinding() error {
+	t.lock.RLock()
+	conn := t.conn
+	t.lock.RUnlock()
+
+	if conn == nil {
+		return fmt.Errorf("%w: unable to write STUN binding", errICETransportNotStarted)
+	}
+
+	agent := t.gatherer.getAgent()
+	if agent == nil {
+		return fmt.Errorf("%w: unable to write STUN binding", errICEAgentNotExist)
+	}
+
+	localUfrag, _, err := agent.GetLocalUserCredentials()
+	if err != nil {
+		return err
+	}
+
+	remoteUfrag, remotePwd, err := agent.GetRemoteUserCredentials()
+	if err != nil {
+		return err
+	}
+
+	// A compliant peer rejects STUN requests that aren't authenticated the
+	// same way the agent's own connectivity checks are: USERNAME identifying
+	// both sides' ufrags, a MESSAGE-INTEGRITY computed with the remote
+	// password, and a trailing FINGERPRINT.
+	msg, err := stun.Build(
+		stun.TransactionID,
+		stun.BindingRequest,
+		stun.NewUsername(remoteUfrag+":"+localUfrag),
+		stun.NewShortTermIntegrity(remotePwd),
+		stun.Fingerprint,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.Write(msg.Raw)
+	return err
+}
+
 func (t *ICETransport) setState(i ICETransportState) {
 	t.state.Store(i)
 }
@@ -389,6 +628,11 @@ func (t *ICETransport) collectStats(collector *statsReportCollector) {
 	}
 
 	collector.Collect(stats.ID, stats)
+
+	for _, pairStats := range t.GetCandidatePairsStats() {
+		collector.Collecting()
+		collector.Collect(pairStats.ID, pairStats)
+	}
 }
 
 func (t *ICETransport) haveRemoteCredentialsChange(newUfrag, newPwd string) bool {
@@ -0,0 +1,209 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+// +build !js
+
+package webrtc
+
+import (
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pion/ice/v4"
+	"github.com/pion/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newConnectedICETransportPair gathers candidates on both sides, exchanges
+// them, and starts both transports (api1 controlling, api2 controlled),
+// returning once both sides report a connection.
+func newConnectedICETransportPair(t *testing.T, api1, api2 *API) (transport1, transport2 *ICETransport, closePair func()) {
+	t.Helper()
+
+	gatherer1, err := api1.NewICEGatherer(ICEGatherOptions{})
+	require.NoError(t, err)
+
+	gatherer2, err := api2.NewICEGatherer(ICEGatherOptions{})
+	require.NoError(t, err)
+
+	transport1 = NewICETransport(gatherer1, api1.settingEngine.LoggerFactory)
+	transport2 = NewICETransport(gatherer2, api2.settingEngine.LoggerFactory)
+
+	exchangeCandidates(t, gatherer1, transport2)
+	exchangeCandidates(t, gatherer2, transport1)
+
+	params1, err := transport1.GetLocalParameters()
+	require.NoError(t, err)
+
+	params2, err := transport2.GetLocalParameters()
+	require.NoError(t, err)
+
+	controlling, controlled := ICERoleControlling, ICERoleControlled
+
+	errCh := make(chan error, 2)
+	go func() { errCh <- transport1.Start(nil, params2, &controlling) }()
+	go func() { errCh <- transport2.Start(nil, params1, &controlled) }()
+
+	require.NoError(t, <-errCh)
+	require.NoError(t, <-errCh)
+
+	return transport1, transport2, func() {
+		require.NoError(t, transport1.Stop())
+		require.NoError(t, transport2.Stop())
+	}
+}
+
+// exchangeCandidates gathers local candidates on gatherer and feeds each one
+// to remote as it arrives.
+func exchangeCandidates(t *testing.T, gatherer *ICEGatherer, remote *ICETransport) {
+	t.Helper()
+
+	done := make(chan struct{})
+	var doneOnce sync.Once
+	gatherer.OnLocalCandidate(func(c *ICECandidate) {
+		if c == nil {
+			// Gathering can complete again later (e.g. after Restart).
+			doneOnce.Do(func() { close(done) })
+			return
+		}
+		require.NoError(t, remote.AddRemoteCandidate(c))
+	})
+
+	require.NoError(t, gatherer.Gather())
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out gathering local candidates")
+	}
+}
+
+// assertEndpointRoundTrip proves that a mux.Endpoint obtained from each side
+// of a connected pair can exchange data, the same shape of endpoint that
+// DTLS/SRTP are built on top of.
+func assertEndpointRoundTrip(t *testing.T, transport1, transport2 *ICETransport) {
+	t.Helper()
+
+	endpoint1 := transport1.newEndpoint(func([]byte) bool { return true })
+	endpoint2 := transport2.newEndpoint(func([]byte) bool { return true })
+
+	payload := []byte("hello over an ICE-TCP candidate pair")
+
+	_, err := endpoint1.Write(payload)
+	require.NoError(t, err)
+
+	buf := make([]byte, len(payload))
+	_, err = endpoint2.Read(buf)
+	require.NoError(t, err)
+	require.Equal(t, payload, buf)
+}
+
+// TestICETransport_TCP connects two ICETransports using only ICE-TCP host
+// candidates, exercising the RFC 4571 framing applied in Start.
+func TestICETransport_TCP(t *testing.T) {
+	loggerFactory := logging.NewDefaultLoggerFactory()
+
+	newTCPMux := func(t *testing.T) ice.TCPMux {
+		t.Helper()
+
+		listener, err := net.ListenTCP("tcp4", &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)})
+		require.NoError(t, err)
+
+		return ice.NewTCPMuxDefault(ice.TCPMuxParams{
+			Listener:       listener,
+			Logger:         loggerFactory.NewLogger("ice-tcp-mux"),
+			ReadBufferSize: 20,
+		})
+	}
+
+	settingEngine1 := SettingEngine{LoggerFactory: loggerFactory}
+	settingEngine1.SetNetworkTypes([]NetworkType{NetworkTypeTCP4})
+	settingEngine1.SetICETCPMux(newTCPMux(t))
+
+	settingEngine2 := SettingEngine{LoggerFactory: loggerFactory}
+	settingEngine2.SetNetworkTypes([]NetworkType{NetworkTypeTCP4})
+	settingEngine2.SetICETCPMux(newTCPMux(t))
+
+	api1 := NewAPI(WithSettingEngine(settingEngine1))
+	api2 := NewAPI(WithSettingEngine(settingEngine2))
+
+	transport1, transport2, closePair := newConnectedICETransportPair(t, api1, api2)
+	defer closePair()
+
+	pair, err := transport1.GetSelectedCandidatePair()
+	require.NoError(t, err)
+	require.Equal(t, ICEProtocolTCP, pair.Local.Protocol)
+	require.Equal(t, ICEProtocolTCP, pair.Remote.Protocol)
+
+	assertEndpointRoundTrip(t, transport1, transport2)
+}
+
+// TestICETransport_Restart exercises the public Restart API: it must rotate
+// the local ICE credentials, re-gather candidates, fire the OnRestart
+// handler, and keep SRTP-style endpoints usable across the restart. It also
+// verifies the renegotiation precondition that a late candidate trickled
+// under the pre-restart ufrag depends on: once the peer learns the rotated
+// credentials, it must stop recognizing the old ones as current.
+func TestICETransport_Restart(t *testing.T) {
+	loggerFactory := logging.NewDefaultLoggerFactory()
+
+	api1 := NewAPI(WithSettingEngine(SettingEngine{LoggerFactory: loggerFactory}))
+	api2 := NewAPI(WithSettingEngine(SettingEngine{LoggerFactory: loggerFactory}))
+
+	transport1, transport2, closePair := newConnectedICETransportPair(t, api1, api2)
+	defer closePair()
+
+	endpoint1 := transport1.newEndpoint(func([]byte) bool { return true })
+	endpoint2 := transport2.newEndpoint(func([]byte) bool { return true })
+
+	before, err := transport1.GetLocalParameters()
+	require.NoError(t, err)
+
+	restarted := make(chan struct{}, 1)
+	transport1.OnRestart(func() { restarted <- struct{}{} })
+
+	require.NoError(t, transport1.Restart(ICEParameters{}))
+
+	select {
+	case <-restarted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for OnRestart handler")
+	}
+
+	after, err := transport1.GetLocalParameters()
+	require.NoError(t, err)
+
+	assert.NotEqual(t, before.UsernameFragment, after.UsernameFragment)
+	assert.NotEqual(t, before.Password, after.Password)
+
+	// The mux/conn backing the pre-restart endpoints must survive the
+	// restart untouched, so SRTP-style traffic keeps flowing on them.
+	payload := []byte("still alive after an ICE restart")
+	_, err = endpoint1.Write(payload)
+	require.NoError(t, err)
+
+	buf := make([]byte, len(payload))
+	_, err = endpoint2.Read(buf)
+	require.NoError(t, err)
+	require.Equal(t, payload, buf)
+
+	// transport2 hasn't been told about transport1's rotated local ufrag/pwd
+	// yet, so it must still regard the pre-restart credentials as current
+	// and the post-restart ones as a change.
+	assert.False(t, transport2.haveRemoteCredentialsChange(before.UsernameFragment, before.Password))
+	assert.True(t, transport2.haveRemoteCredentialsChange(after.UsernameFragment, after.Password))
+
+	// Once transport2 is told about the rotation (as it would be via a
+	// renegotiated SDP in a full PeerConnection), it adopts the new
+	// credentials as current and recognizes the old ones as stale. A
+	// connectivity check trickling in afterwards under the old ufrag is
+	// rejected by the ICE agent itself at the STUN/USERNAME layer, which is
+	// exercised by pion/ice's own agent tests rather than duplicated here.
+	require.NoError(t, transport2.setRemoteCredentials(after.UsernameFragment, after.Password))
+	assert.True(t, transport2.haveRemoteCredentialsChange(before.UsernameFragment, before.Password))
+	assert.False(t, transport2.haveRemoteCredentialsChange(after.UsernameFragment, after.Password))
+}
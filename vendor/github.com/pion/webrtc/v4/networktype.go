@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+// +build !js
+
+package webrtc
+
+import "github.com/pion/ice/v4"
+
+// NetworkType represents the type of network.
+type NetworkType int
+
+const (
+	// NetworkTypeUDP4 indicates UDP over IPv4.
+	NetworkTypeUDP4 NetworkType = iota + 1
+
+	// NetworkTypeUDP6 indicates UDP over IPv6.
+	NetworkTypeUDP6
+
+	// NetworkTypeTCP4 indicates TCP over IPv4.
+	NetworkTypeTCP4
+
+	// NetworkTypeTCP6 indicates TCP over IPv6.
+	NetworkTypeTCP6
+)
+
+func (t NetworkType) String() string {
+	switch t {
+	case NetworkTypeUDP4:
+		return "udp4"
+	case NetworkTypeUDP6:
+		return "udp6"
+	case NetworkTypeTCP4:
+		return "tcp4"
+	case NetworkTypeTCP6:
+		return "tcp6"
+	default:
+		return ErrUnknownType.Error()
+	}
+}
+
+func (t NetworkType) toICE() ice.NetworkType {
+	switch t {
+	case NetworkTypeUDP4:
+		return ice.NetworkTypeUDP4
+	case NetworkTypeUDP6:
+		return ice.NetworkTypeUDP6
+	case NetworkTypeTCP4:
+		return ice.NetworkTypeTCP4
+	case NetworkTypeTCP6:
+		return ice.NetworkTypeTCP6
+	default:
+		return ice.NetworkType(0)
+	}
+}
+
+func networkTypesToICE(types []NetworkType) []ice.NetworkType {
+	iceTypes := make([]ice.NetworkType, 0, len(types))
+	for _, t := range types {
+		iceTypes = append(iceTypes, t.toICE())
+	}
+
+	return iceTypes
+}
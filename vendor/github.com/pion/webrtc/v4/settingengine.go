@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+// +build !js
+
+package webrtc
+
+import (
+	"time"
+
+	"github.com/pion/ice/v4"
+	"github.com/pion/logging"
+)
+
+// SettingEngine allows influencing behavior in ways that are not supported
+// by the WebRTC API. This allows us to support additional use-cases without
+// deviating from the WebRTC API elsewhere.
+type SettingEngine struct {
+	LoggerFactory logging.LoggerFactory
+
+	candidates struct {
+		UsernameFragment string
+		Password         string
+		MulticastDNSMode ICEMulticastDNSMode
+	}
+
+	networkTypes []NetworkType
+	iceTCPMux    ice.TCPMux
+
+	iceTimeouts struct {
+		disconnectedTimeout *time.Duration
+		failedTimeout       *time.Duration
+		keepAliveInterval   *time.Duration
+	}
+	iceConsentFreshness *bool
+
+	candidatePairSelector func([]ICECandidatePairStats) *ICECandidatePair
+
+	receiveMTU uint
+}
+
+// defaultReceiveMTU is used by getReceiveMTU when no explicit value has been
+// configured via SettingEngine.
+const defaultReceiveMTU = 8192
+
+func (e *SettingEngine) getReceiveMTU() uint {
+	if e.receiveMTU != 0 {
+		return e.receiveMTU
+	}
+
+	return defaultReceiveMTU
+}
+
+// SetICEMulticastDNSMode controls whether the ICE agent can query and/or
+// gather mDNS ("*.local") candidates. Defaults to
+// ICEMulticastDNSModeDisabled.
+func (e *SettingEngine) SetICEMulticastDNSMode(mode ICEMulticastDNSMode) {
+	e.candidates.MulticastDNSMode = mode
+}
+
+// SetNetworkTypes restricts which network types (UDP/TCP, IPv4/IPv6) the ICE
+// agent is allowed to gather and use for connectivity checks. Passing
+// NetworkTypeTCP4/NetworkTypeTCP6 requires a TCPMux to be configured via
+// SetICETCPMux, otherwise no TCP candidates will be produced.
+func (e *SettingEngine) SetNetworkTypes(types []NetworkType) {
+	e.networkTypes = types
+}
+
+// SetICETCPMux enables ICE-TCP host candidates by providing the ice.TCPMux
+// used to accept/dial TCP candidate pairs.
+func (e *SettingEngine) SetICETCPMux(tcpMux ice.TCPMux) {
+	e.iceTCPMux = tcpMux
+}
+
+// SetICETimeouts sets the disconnected, failed and keepAlive timeouts used
+// by the ICE agent. disconnected fires first and gives the application a
+// chance to react to transient connectivity loss; failed gives up on the
+// connection entirely. keepAlive controls how often the agent sends
+// consent-freshness/keepalive STUN binding requests on an otherwise idle
+// connection. A zero value for any of them leaves the ice.Agent default in
+// place.
+func (e *SettingEngine) SetICETimeouts(disconnectedTimeout, failedTimeout, keepAliveInterval time.Duration) {
+	if disconnectedTimeout != 0 {
+		e.iceTimeouts.disconnectedTimeout = &disconnectedTimeout
+	}
+	if failedTimeout != 0 {
+		e.iceTimeouts.failedTimeout = &failedTimeout
+	}
+	if keepAliveInterval != 0 {
+		e.iceTimeouts.keepAliveInterval = &keepAliveInterval
+	}
+}
+
+// SetICEConsentFreshness enables or disables RFC 7675 consent freshness
+// checks on the ICE agent. Disabling it is only safe when the application
+// takes over liveness checking itself, e.g. via ICETransport.WriteSTUNBinding.
+func (e *SettingEngine) SetICEConsentFreshness(enabled bool) {
+	e.iceConsentFreshness = &enabled
+}
+
+// SetICECandidatePairSelector installs a hook that is consulted every time
+// every known candidate pair has reached StatsICECandidatePairStateSucceeded.
+// Its return value, if non-nil, is nominated in place of whichever pair the
+// ICE agent would otherwise have chosen. It is invoked from the agent's own
+// task loop, so it must not block and must not call back into the
+// ICETransport/ICEGatherer that owns it.
+func (e *SettingEngine) SetICECandidatePairSelector(selector func([]ICECandidatePairStats) *ICECandidatePair) {
+	e.candidatePairSelector = selector
+}
+
+// toICEAgentConfig translates the ICE-related SettingEngine options into the
+// ice.AgentConfig used to construct the ice.Agent backing an ICEGatherer.
+func (e *SettingEngine) toICEAgentConfig() *ice.AgentConfig {
+	config := &ice.AgentConfig{
+		LoggerFactory:       e.LoggerFactory,
+		NetworkTypes:        networkTypesToICE(e.networkTypes),
+		TCPMux:              e.iceTCPMux,
+		DisconnectedTimeout: e.iceTimeouts.disconnectedTimeout,
+		FailedTimeout:       e.iceTimeouts.failedTimeout,
+		KeepaliveInterval:   e.iceTimeouts.keepAliveInterval,
+		ConsentFreshness:    e.iceConsentFreshness,
+	}
+
+	switch e.candidates.MulticastDNSMode {
+	case ICEMulticastDNSModeQueryOnly:
+		config.MulticastDNSMode = ice.MulticastDNSModeQueryOnly
+	case ICEMulticastDNSModeQueryAndGather:
+		config.MulticastDNSMode = ice.MulticastDNSModeQueryAndGather
+	default:
+		config.MulticastDNSMode = ice.MulticastDNSModeDisabled
+	}
+
+	return config
+}
@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+// +build !js
+
+package webrtc
+
+import (
+	"sync"
+	"time"
+)
+
+// StatsTimestamp is a timestamp represented by the number of milliseconds
+// since the Unix epoch.
+type StatsTimestamp float64
+
+// statsTimestampFrom converts a time.Time to a StatsTimestamp.
+func statsTimestampFrom(t time.Time) StatsTimestamp {
+	return StatsTimestamp(t.UnixNano() / int64(time.Millisecond))
+}
+
+// StatsType indicates the type of the object that a Stats object
+// represents.
+type StatsType string
+
+const (
+	// StatsTypeTransport is used by TransportStats.
+	StatsTypeTransport StatsType = "transport"
+
+	// StatsTypeCandidatePair is used by ICECandidatePairStats.
+	StatsTypeCandidatePair StatsType = "candidate-pair"
+)
+
+// TransportStats contains transport statistics related to the
+// ICETransport object.
+type TransportStats struct {
+	Timestamp     StatsTimestamp
+	Type          StatsType
+	ID            string
+	BytesSent     uint64
+	BytesReceived uint64
+}
+
+// StatsICECandidatePairState represents the state of an ICE candidate pair,
+// as reported on ICECandidatePairStats.
+type StatsICECandidatePairState string
+
+const (
+	// StatsICECandidatePairStateWaiting means the pair is waiting for its
+	// turn to run connectivity checks.
+	StatsICECandidatePairStateWaiting StatsICECandidatePairState = "waiting"
+
+	// StatsICECandidatePairStateInProgress means a connectivity check is in
+	// flight for this pair.
+	StatsICECandidatePairStateInProgress StatsICECandidatePairState = "in-progress"
+
+	// StatsICECandidatePairStateFailed means every connectivity check for
+	// this pair has failed.
+	StatsICECandidatePairStateFailed StatsICECandidatePairState = "failed"
+
+	// StatsICECandidatePairStateSucceeded means at least one connectivity
+	// check for this pair has succeeded.
+	StatsICECandidatePairStateSucceeded StatsICECandidatePairState = "succeeded"
+)
+
+// ICECandidatePairStats contains ICE candidate pair statistics related to
+// the ICETransport objects, as surfaced by ICETransport.GetCandidatePairsStats
+// and ICETransport.GetSelectedCandidatePairStats.
+type ICECandidatePairStats struct {
+	Timestamp StatsTimestamp
+	Type      StatsType
+	ID        string
+	LocalID   string
+	RemoteID  string
+
+	// Local and Remote are the candidates LocalID/RemoteID refer to. Each is
+	// populated only while the ICE agent still knows about that candidate
+	// (one can be set while the other is nil), and they exist so a SettingEngine candidate pair selector has something it
+	// can hand back to ICETransport.maybeSelectCandidatePair.
+	Local  *ICECandidate
+	Remote *ICECandidate
+
+	State         StatsICECandidatePairState
+	Nominated     bool
+	BytesSent     uint64
+	BytesReceived uint64
+
+	TotalRoundTripTime   float64
+	CurrentRoundTripTime float64
+
+	RequestsSent      uint32
+	RequestsReceived  uint32
+	ResponsesSent     uint32
+	ResponsesReceived uint32
+
+	LastPacketSentTimestamp     StatsTimestamp
+	LastPacketReceivedTimestamp StatsTimestamp
+}
+
+// StatsReport collects the Stats objects emitted by a PeerConnection and its
+// underlying transports, keyed by stats ID.
+type StatsReport map[string]interface{}
+
+// statsReportCollector gathers stats objects from potentially many
+// goroutines (one per transport) into a single StatsReport.
+type statsReportCollector struct {
+	collecting sync.WaitGroup
+	lock       sync.Mutex
+	report     StatsReport
+}
+
+func newStatsReportCollector() *statsReportCollector {
+	return &statsReportCollector{report: StatsReport{}}
+}
+
+// Collecting marks one more stats object as pending; call before handing the
+// collector to a goroutine that will eventually call Collect.
+func (c *statsReportCollector) Collecting() {
+	c.collecting.Add(1)
+}
+
+// Collect records a stats object under id and marks it no longer pending.
+func (c *statsReportCollector) Collect(id string, stats interface{}) {
+	defer c.collecting.Done()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.report[id] = stats
+}
+
+// Ready blocks until every Collecting call has been matched by a Collect,
+// then returns the accumulated report.
+func (c *statsReportCollector) Ready() StatsReport {
+	c.collecting.Wait()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	report := make(StatsReport, len(c.report))
+	for id, s := range c.report {
+		report[id] = s
+	}
+
+	return report
+}